@@ -7,6 +7,8 @@ package args
 import (
 	"fmt"
 	"io"
+	"io/ioutil"
+	"os"
 	"reflect"
 	"strconv"
 	"strings"
@@ -17,12 +19,45 @@ import (
 	"github.com/scaleway/scaleway-sdk-go/validation"
 )
 
+var readerType = reflect.TypeOf((*io.Reader)(nil)).Elem()
+
 type Unmarshaler interface {
 	UnmarshalArgs(value string) error
 }
 
 type UnmarshalFunc func(value string, dest interface{}) error
 
+// argAliases maps an alias to the canonical argument name it stands for.
+// It is populated by RegisterAlias.
+var argAliases = map[string]string{}
+
+// RegisterAlias registers alias as an alternative name for the canonical
+// argument name. It is meant for aliases that make sense across several
+// commands, e.g. "org" for "organization-id".
+//
+// RegisterAlias returns an AmbiguousArgAliasError if alias is already
+// registered for a different canonical name, as this would make argument
+// resolution ambiguous. It never panics: callers (typically a command's
+// explicit registration step, not a package init) decide how to surface
+// the error instead of crashing the whole binary over an unrelated
+// command's conflicting alias.
+func RegisterAlias(alias string, name string) error {
+	if existing, exists := argAliases[alias]; exists && existing != name {
+		return &AmbiguousArgAliasError{Alias: alias, Names: []string{existing, name}}
+	}
+	argAliases[alias] = name
+	return nil
+}
+
+// resolveArgAlias resolves argName to its canonical form using the
+// aliases registered through RegisterAlias.
+func resolveArgAlias(argName string) string {
+	if name, exists := argAliases[argName]; exists {
+		return name
+	}
+	return argName
+}
+
 var unmarshalFuncs = map[reflect.Type]UnmarshalFunc{
 	reflect.TypeOf((*scw.Size)(nil)).Elem(): func(value string, dest interface{}) error {
 		// Only support G, GB for now (case insensitive).
@@ -41,17 +76,40 @@ var unmarshalFuncs = map[reflect.Type]UnmarshalFunc{
 	reflect.TypeOf((*scw.IPNet)(nil)).Elem(): func(value string, dest interface{}) error {
 		return dest.(*scw.IPNet).UnmarshalJSON([]byte(`"` + value + `"`))
 	},
-	reflect.TypeOf((*io.Reader)(nil)).Elem(): func(value string, dest interface{}) error {
-		*(dest.(*io.Reader)) = strings.NewReader(value)
+	readerType: func(value string, dest interface{}) error {
+		reader, err := resolveArgReader(value)
+		if err != nil {
+			return err
+		}
+		*(dest.(*io.Reader)) = reader
 		return nil
 	},
 }
 
+// FileExpansionFunc reports whether the @file/@- expansion performed by
+// resolveArgValue must be applied for the given argument name, i.e. the
+// full name as passed on the command line (e.g. "volumes.0.user-data").
+//
+// Expansion is opt-in: unless the func returns true for an argument, a
+// value starting with "@" is kept as-is, so an existing string argument
+// whose legitimate value can start with "@" (an email, a handle, ...) is
+// never silently reinterpreted as a file to read. io.Reader fields are not
+// covered by this func: they always resolve @file/@- (see set).
+//
+// The func is supplied by the caller of UnmarshalStruct; when none is
+// given, no argument is expanded.
+type FileExpansionFunc func(argName string) bool
+
 // UnmarshalStruct parses args like ["arg1=1", "arg2=2"] to a Go structure using reflection.
 //
 // args: slice of args passed through the command line
 // data: Go structure to fill
-func UnmarshalStruct(args []string, data interface{}) error {
+// fileExpansion: optional, reports which argument names (as passed in args) opt into @file/@- expansion
+func UnmarshalStruct(args []string, data interface{}, fileExpansion ...FileExpansionFunc) error {
+	var expandFile FileExpansionFunc
+	if len(fileExpansion) > 0 {
+		expandFile = fileExpansion[0]
+	}
 
 	// First check if we want to retrieve a simple []string
 	if raw, ok := data.(*RawArgs); ok {
@@ -105,8 +163,10 @@ func UnmarshalStruct(args []string, data interface{}) error {
 		}
 		processedArgNames[argName] = true
 
+		expand := expandFile != nil && expandFile(argName)
+
 		// Set will recursively find the correct field to set.
-		err := set(dest, strings.Split(argName, "."), argValue)
+		err := set(dest, strings.Split(argName, "."), argValue, expand)
 		if err != nil {
 			return &UnmarshalArgError{
 				ArgName:  argName,
@@ -145,9 +205,10 @@ func RegisterUnmarshalFunc(i interface{}, unmarshalFunc UnmarshalFunc) {
 // dest: the structure to be completed
 // argNameWords: the name of the argument to set
 // value: the value to be set, represented as a string
+// expand: when true, value goes through @file/@- expansion before being set
 //
 // Example: argNameWords ["contacts", "0", "address", "city"] will set value "city" for your first contact in your phone book.
-func set(dest reflect.Value, argNameWords []string, value string) error {
+func set(dest reflect.Value, argNameWords []string, value string, expand bool) error {
 
 	// If dest has a custom unmarshaler, we use it.
 	// dest can either implement Unmarshaler
@@ -164,7 +225,24 @@ func set(dest reflect.Value, argNameWords []string, value string) error {
 			dest.Set(reflect.New(dest.Type().Elem()))
 			dest = dest.Elem()
 		}
-		return unmarshalValue(value, dest)
+
+		// io.Reader fields resolve @file/@- themselves, streaming the
+		// content instead of loading it in memory: that is the whole
+		// reason a command declares a Reader field instead of a string
+		// one, so this is not gated behind expand.
+		if dest.Type() == readerType {
+			return unmarshalValue(value, dest)
+		}
+
+		if !expand {
+			return unmarshalValue(value, dest)
+		}
+
+		resolvedValue, err := resolveArgValue(value)
+		if err != nil {
+			return &CannotUnmarshalError{Dest: dest.Addr().Interface(), Err: err}
+		}
+		return unmarshalValue(resolvedValue, dest)
 	}
 
 	switch dest.Kind() {
@@ -175,7 +253,7 @@ func set(dest reflect.Value, argNameWords []string, value string) error {
 		}
 
 		// Call set with the pointer.Elem()
-		return set(dest.Elem(), argNameWords, value)
+		return set(dest.Elem(), argNameWords, value, expand)
 
 	case reflect.Slice:
 		// If type is a slice:
@@ -205,7 +283,7 @@ func set(dest reflect.Value, argNameWords []string, value string) error {
 		}
 
 		// Recursively call set without the index word
-		return set(dest.Index(int(index)), argNameWords[1:], value)
+		return set(dest.Index(int(index)), argNameWords[1:], value, expand)
 
 	case reflect.Map:
 		// If map is nil we create it.
@@ -217,7 +295,7 @@ func set(dest reflect.Value, argNameWords []string, value string) error {
 		}
 		// Create a new value call set and add result in the map
 		newValue := reflect.New(dest.Type().Elem())
-		err := set(newValue.Elem(), argNameWords[1:], value)
+		err := set(newValue.Elem(), argNameWords[1:], value, expand)
 		dest.SetMapIndex(reflect.ValueOf(argNameWords[0]), newValue.Elem())
 		return err
 
@@ -246,14 +324,14 @@ func set(dest reflect.Value, argNameWords []string, value string) error {
 		}
 
 		// Try to find the correct field in the current struct.
-		fieldName := strcase.ToPublicGoName(argNameWords[0])
+		fieldName := strcase.ToPublicGoName(resolveArgAlias(argNameWords[0]))
 		if fieldIndex, exist := fieldIndexByName[fieldName]; exist {
-			return set(dest.Field(fieldIndex), argNameWords[1:], value)
+			return set(dest.Field(fieldIndex), argNameWords[1:], value, expand)
 		}
 
 		// If it does not exist we try to find it in nested anonymous field
 		for i := len(anonymousFieldIndexes) - 1; i >= 0; i-- {
-			err := set(dest.Field(anonymousFieldIndexes[i]), argNameWords, value)
+			err := set(dest.Field(anonymousFieldIndexes[i]), argNameWords, value, expand)
 			switch err.(type) {
 			case nil:
 				// If we got no error the field was correctly set we return nil.
@@ -376,4 +454,45 @@ func unmarshalValue(value string, dest reflect.Value) error {
 	return &CannotUnmarshalError{
 		Dest: dest.Interface(),
 	}
-}
\ No newline at end of file
+}
+
+// resolveArgValue expands the @file and @- syntax: a value starting with
+// "@" is read from the file at the given path, or from stdin when the
+// path is "-". Prefixing with "@@" escapes this and yields the literal
+// value starting with a single "@".
+//
+// This lets values too large or cumbersome for a single shell argument
+// (cloud-init user data, SSH keys, certificates, ...) be loaded from a
+// file instead.
+func resolveArgValue(value string) (string, error) {
+	switch {
+	case strings.HasPrefix(value, "@@"):
+		return value[1:], nil
+	case value == "@-":
+		content, err := ioutil.ReadAll(os.Stdin)
+		return string(content), err
+	case strings.HasPrefix(value, "@"):
+		content, err := ioutil.ReadFile(value[1:])
+		return string(content), err
+	default:
+		return value, nil
+	}
+}
+
+// resolveArgReader is the streaming counterpart of resolveArgValue: it is
+// used for io.Reader fields so a file passed with @ is streamed rather
+// than loaded entirely in memory.
+func resolveArgReader(value string) (io.Reader, error) {
+	switch {
+	case strings.HasPrefix(value, "@@"):
+		return strings.NewReader(value[1:]), nil
+	case value == "@-":
+		return os.Stdin, nil
+	case strings.HasPrefix(value, "@"):
+		// The returned *os.File is never closed here: it is left open for
+		// the lifetime of the process, same as os.Stdin above.
+		return os.Open(value[1:])
+	default:
+		return strings.NewReader(value), nil
+	}
+}
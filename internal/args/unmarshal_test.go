@@ -0,0 +1,190 @@
+package args
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// resetArgAliasesForTest clears the package-level alias table between
+// tests so RegisterAlias calls do not leak across test cases.
+func resetArgAliasesForTest() {
+	for k := range argAliases {
+		delete(argAliases, k)
+	}
+}
+
+func TestRegisterAlias(t *testing.T) {
+	defer resetArgAliasesForTest()
+
+	if err := RegisterAlias("org", "organization-id"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Registering the same alias for the same canonical name again is fine.
+	if err := RegisterAlias("org", "organization-id"); err != nil {
+		t.Fatalf("unexpected error on re-registration: %s", err)
+	}
+
+	err := RegisterAlias("org", "owner-id")
+	if err == nil {
+		t.Fatal("expected an error for a conflicting alias, got nil")
+	}
+	if _, ok := err.(*AmbiguousArgAliasError); !ok {
+		t.Fatalf("expected an *AmbiguousArgAliasError, got %T", err)
+	}
+}
+
+func TestUnmarshalStructResolvesAlias(t *testing.T) {
+	defer resetArgAliasesForTest()
+
+	if err := RegisterAlias("org", "organization-id"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	type testStruct struct {
+		OrganizationID string
+	}
+
+	dest := &testStruct{}
+	err := UnmarshalStruct([]string{"org=11111111-1111-1111-1111-111111111111"}, dest)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := "11111111-1111-1111-1111-111111111111"
+	if dest.OrganizationID != expected {
+		t.Fatalf("expected OrganizationID to be %q, got %q", expected, dest.OrganizationID)
+	}
+}
+
+func TestResolveArgValue(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "user-data.yaml")
+	if err := ioutil.WriteFile(path, []byte("hello from file"), 0o600); err != nil {
+		t.Fatalf("could not write fixture file: %s", err)
+	}
+
+	cases := []struct {
+		name     string
+		value    string
+		expected string
+	}{
+		{name: "plain value", value: "hello", expected: "hello"},
+		{name: "file value", value: "@" + path, expected: "hello from file"},
+		{name: "escaped at", value: "@@not-a-file", expected: "@not-a-file"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := resolveArgValue(c.value)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != c.expected {
+				t.Fatalf("expected %q, got %q", c.expected, got)
+			}
+		})
+	}
+}
+
+func TestResolveArgValueMissingFile(t *testing.T) {
+	_, err := resolveArgValue("@/does/not/exist")
+	if err == nil {
+		t.Fatal("expected an error for a missing file, got nil")
+	}
+}
+
+func TestResolveArgReaderStreamsFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "cert.pem")
+	content := "-----BEGIN CERTIFICATE-----"
+	if err := ioutil.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("could not write fixture file: %s", err)
+	}
+
+	reader, err := resolveArgReader("@" + path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer func() {
+		if closer, ok := reader.(io.Closer); ok {
+			closer.Close()
+		}
+	}()
+
+	got, err := ioutil.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("unexpected error reading: %s", err)
+	}
+	if string(got) != content {
+		t.Fatalf("expected %q, got %q", content, string(got))
+	}
+}
+
+func TestUnmarshalStructDefaultsToNoFileExpansion(t *testing.T) {
+	type testStruct struct {
+		Handle string
+	}
+
+	dest := &testStruct{}
+
+	err := UnmarshalStruct([]string{"handle=@someone"}, dest)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if dest.Handle != "@someone" {
+		t.Fatalf("expected Handle to be %q, got %q", "@someone", dest.Handle)
+	}
+}
+
+func TestUnmarshalStructFileExpansionOptIn(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "user-data.yaml")
+	if err := ioutil.WriteFile(path, []byte("hello from file"), 0o600); err != nil {
+		t.Fatalf("could not write fixture file: %s", err)
+	}
+
+	type testStruct struct {
+		UserData string
+	}
+
+	dest := &testStruct{}
+	expandFile := func(argName string) bool { return argName == "user-data" }
+
+	err := UnmarshalStruct([]string{"user-data=@" + path}, dest, FileExpansionFunc(expandFile))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if dest.UserData != "hello from file" {
+		t.Fatalf("expected UserData to be %q, got %q", "hello from file", dest.UserData)
+	}
+}
+
+func TestResolveArgValueStdin(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("could not create pipe: %s", err)
+	}
+
+	origStdin := os.Stdin
+	os.Stdin = r
+	defer func() { os.Stdin = origStdin }()
+
+	go func() {
+		w.Write([]byte("hello from stdin"))
+		w.Close()
+	}()
+
+	got, err := resolveArgValue("@-")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got != "hello from stdin" {
+		t.Fatalf("expected %q, got %q", "hello from stdin", got)
+	}
+}
@@ -5,12 +5,60 @@ import (
 	"fmt"
 	"reflect"
 
+	"github.com/scaleway/scaleway-cli/internal/args"
 	"github.com/scaleway/scaleway-cli/internal/core"
 	baremetal "github.com/scaleway/scaleway-sdk-go/api/baremetal/v1alpha1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 )
 
+// registerArgAliases registers this command's argument aliases. It is
+// called from serverCreateBuilder, i.e. while this specific command is
+// being registered, rather than from a package init(): a conflicting
+// alias must only fail the registration of this command, not crash the
+// whole binary for unrelated commands.
+func registerArgAliases() error {
+	// "org" is a cross-command shorthand for "organization-id".
+	if err := args.RegisterAlias("org", "organization-id"); err != nil {
+		return err
+	}
+	// offer-id is the legacy name for the "type" argument: keep accepting it.
+	return args.RegisterAlias("offer-id", "type")
+}
+
+// staticOfferTypes lists the commercial offer types accepted for the
+// "type" argument.
+//
+// This is a static, hand-maintained list rather than one sourced from
+// api.ListOffers: ArgSpec has no hook for an API-backed, per-zone enum yet
+// (it would need a context.Context and a client, neither of which
+// EnumValues has access to), so until core grows that hook this stays a
+// plain slice.
+var staticOfferTypes = []string{
+	// General Purpose offers
+	"GP-BM1-L",
+	"GP-BM1-M",
+	"GP-BM1-S",
+
+	// High-computing offers
+	"HC-BM1-L",
+	"HC-BM1-S",
+
+	// High-Memory offers
+	"HM-BM1-XL",
+	"HM-BM1-M",
+}
+
 func serverCreateBuilder(c *core.Command) *core.Command {
+	if err := registerArgAliases(); err != nil {
+		// Surface a conflicting alias as a normal command error instead of
+		// panicking at registration time, which would crash the whole
+		// binary for commands unrelated to this one.
+		c.Run = func(ctx context.Context, argsI interface{}) (interface{}, error) {
+			return nil, err
+		}
+		return c
+	}
+
 	type baremetalCreateServerRequestCustom struct {
 		Zone scw.Zone `json:"-"`
 		// OrganizationID with which the server will be created
@@ -37,20 +85,7 @@ func serverCreateBuilder(c *core.Command) *core.Command {
 		Short:   "Server commercial type",
 		Default: core.DefaultValueSetter("GP-BM1-S"),
 
-		EnumValues: []string{
-			// General Purpose offers
-			"GP-BM1-L",
-			"GP-BM1-M",
-			"GP-BM1-S",
-
-			// High-computing offers
-			"HC-BM1-L",
-			"HC-BM1-S",
-
-			// High-Memory offers
-			"HM-BM1-XL",
-			"HM-BM1-M",
-		},
+		EnumValues: staticOfferTypes,
 	})
 
 	c.Run = func(ctx context.Context, argsI interface{}) (i interface{}, e error) {
@@ -116,4 +151,4 @@ func serverCreateBuilder(c *core.Command) *core.Command {
 	}
 
 	return c
-}
\ No newline at end of file
+}